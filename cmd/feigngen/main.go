@@ -0,0 +1,417 @@
+// Command feigngen generates zero-reflection feign clients.
+//
+// It walks a package directory looking for structs that carry a blank,
+// feign-tagged "_ struct{}" field naming the base URL (the same DSL the
+// runtime reflection fallback in feign.Client.Create understands) and, for
+// every named, feign-tagged func-typed field, emits a hand-written closure
+// that builds a feign.Request and sends it through the client's interceptor
+// chain (Client.DoDiscovered, or feign.Stream for @STREAM) - no
+// reflect.MakeFunc, no go/parser at runtime, and malformed tags fail the go generate build
+// instead of panicking in production.
+//
+// Usage:
+//
+//	//go:generate go run github.com/kimxuanhong/go-feign/cmd/feigngen -dir .
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+// clientSpec describes one feign client struct found in source.
+type clientSpec struct {
+	StructName string
+	BaseURL    string
+	Methods    []methodSpec
+}
+
+// methodSpec describes one generated method closure.
+type methodSpec struct {
+	Name       string
+	HasCtx     bool
+	ParamNames []string // excludes ctx
+	ParamTypes []string // excludes ctx, parallel to ParamNames
+	ResultType string   // e.g. "*User", "[]User", "User"
+	Meta       feign.MethodMeta
+}
+
+func main() {
+	dir := flag.String("dir", ".", "package directory to scan for feign-tagged structs")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, *dir, nil, parser.ParseComments)
+	if err != nil {
+		log.Fatalf("feigngen: parse %s: %v", *dir, err)
+	}
+
+	for _, pkg := range pkgs {
+		for filename, file := range pkg.Files {
+			specs, err := collectClientSpecs(fset, file)
+			if err != nil {
+				log.Fatalf("feigngen: %s: %v", filename, err)
+			}
+			if len(specs) == 0 {
+				continue
+			}
+
+			src, err := generateFile(pkg.Name, specs)
+			if err != nil {
+				log.Fatalf("feigngen: generate for %s: %v", filename, err)
+			}
+
+			outPath := outputPath(filename)
+			if err := os.WriteFile(outPath, src, 0644); err != nil {
+				log.Fatalf("feigngen: write %s: %v", outPath, err)
+			}
+			fmt.Printf("feigngen: generated %s\n", outPath)
+		}
+	}
+}
+
+func outputPath(srcFile string) string {
+	ext := filepath.Ext(srcFile)
+	base := strings.TrimSuffix(srcFile, ext)
+	return base + "_feign.go"
+}
+
+// collectClientSpecs finds every feign client struct declared in file.
+func collectClientSpecs(fset *token.FileSet, file *ast.File) ([]clientSpec, error) {
+	var specs []clientSpec
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			baseURL, ok, err := findBaseURL(structType)
+			if err != nil {
+				return nil, fmt.Errorf("struct %s: %w", typeSpec.Name.Name, err)
+			}
+			if !ok {
+				continue
+			}
+
+			cs := clientSpec{StructName: typeSpec.Name.Name, BaseURL: baseURL}
+			for _, field := range structType.Fields.List {
+				if len(field.Names) == 0 || field.Names[0].Name == "_" {
+					continue
+				}
+				funcType, ok := field.Type.(*ast.FuncType)
+				if !ok {
+					continue
+				}
+				tag, ok := feignTagValue(field.Tag)
+				if !ok {
+					continue
+				}
+
+				ms, err := buildMethodSpec(fset, field.Names[0].Name, funcType, tag)
+				if err != nil {
+					return nil, fmt.Errorf("method %s.%s: %w", typeSpec.Name.Name, field.Names[0].Name, err)
+				}
+				cs.Methods = append(cs.Methods, ms)
+			}
+
+			if len(cs.Methods) > 0 {
+				specs = append(specs, cs)
+			}
+		}
+	}
+
+	return specs, nil
+}
+
+// findBaseURL looks for the conventional blank "_ struct{}" field carrying the @Url tag.
+func findBaseURL(structType *ast.StructType) (string, bool, error) {
+	for _, field := range structType.Fields.List {
+		if len(field.Names) != 1 || field.Names[0].Name != "_" {
+			continue
+		}
+		tag, ok := feignTagValue(field.Tag)
+		if !ok {
+			continue
+		}
+		meta, err := feign.ParseTag(tag)
+		if err != nil {
+			return "", false, err
+		}
+		if meta.Url == "" {
+			continue
+		}
+		return meta.Url, true, nil
+	}
+	return "", false, nil
+}
+
+func feignTagValue(tag *ast.BasicLit) (string, bool) {
+	if tag == nil {
+		return "", false
+	}
+	raw := strings.Trim(tag.Value, "`")
+	value := reflect.StructTag(raw).Get("feign")
+	return value, value != ""
+}
+
+func buildMethodSpec(fset *token.FileSet, name string, funcType *ast.FuncType, tag string) (methodSpec, error) {
+	meta, err := feign.ParseTag(tag)
+	if err != nil {
+		return methodSpec{}, err
+	}
+	if meta.Method == "" || meta.Path == "" {
+		return methodSpec{}, fmt.Errorf("missing HTTP method or path in tag %q", tag)
+	}
+
+	ms := methodSpec{Name: name, Meta: meta}
+
+	var names, types []string
+	for _, p := range funcType.Params.List {
+		typ := exprString(fset, p.Type)
+		if len(p.Names) == 0 {
+			names = append(names, "_")
+			types = append(types, typ)
+			continue
+		}
+		for _, n := range p.Names {
+			names = append(names, n.Name)
+			types = append(types, typ)
+		}
+	}
+	if len(names) > 0 && types[0] == "context.Context" {
+		ms.HasCtx = true
+		names, types = names[1:], types[1:]
+	}
+	ms.ParamNames, ms.ParamTypes = names, types
+
+	wantParams := 0
+	if meta.BodyParam != "" {
+		wantParams++
+	}
+	wantParams += len(meta.Parts)
+	wantParams += len(meta.PathVars) + len(meta.Queries) + len(meta.Headers)
+	if wantParams != len(names) {
+		return methodSpec{}, fmt.Errorf("tag %q expects %d parameters (excluding ctx), method has %d", tag, wantParams, len(names))
+	}
+
+	if funcType.Results == nil || len(funcType.Results.List) != 2 {
+		return methodSpec{}, fmt.Errorf("method must return (T, error)")
+	}
+	firstResult := exprString(fset, funcType.Results.List[0].Type)
+	secondResult := exprString(fset, funcType.Results.List[1].Type)
+
+	if meta.Stream {
+		elem, ok := strings.CutPrefix(firstResult, "<-chan ")
+		if !ok {
+			return methodSpec{}, fmt.Errorf("@STREAM method must return (<-chan T, <-chan error), got first return %q", firstResult)
+		}
+		if secondResult != "<-chan error" {
+			return methodSpec{}, fmt.Errorf("@STREAM method must return (<-chan T, <-chan error), got second return %q", secondResult)
+		}
+		ms.ResultType = elem
+	} else {
+		ms.ResultType = firstResult
+	}
+
+	return ms, nil
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+func generateFile(pkgName string, specs []clientSpec) ([]byte, error) {
+	var buf bytes.Buffer
+
+	needsStrings, needsFmt := false, false
+	for _, cs := range specs {
+		for _, m := range cs.Methods {
+			if len(m.Meta.PathVars) > 0 {
+				needsStrings = true
+			}
+			// fmt.Sprintf for templated params, and fmt.Errorf wrapping a
+			// marshal/unmarshal failure - every non-stream method emits at
+			// least the latter.
+			if len(m.Meta.PathVars)+len(m.Meta.Queries)+len(m.Meta.Headers) > 0 || m.Meta.BodyParam != "" || !m.Meta.Stream {
+				needsFmt = true
+			}
+		}
+	}
+
+	buf.WriteString("// Code generated by feigngen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"context\"\n")
+	if needsFmt {
+		buf.WriteString("\t\"fmt\"\n")
+	}
+	if needsStrings {
+		buf.WriteString("\t\"strings\"\n")
+	}
+	buf.WriteString("\n\t\"github.com/kimxuanhong/go-feign/feign\"\n")
+	buf.WriteString(")\n\n")
+
+	for _, cs := range specs {
+		writeConstructor(&buf, cs)
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func writeConstructor(buf *bytes.Buffer, cs clientSpec) {
+	fmt.Fprintf(buf, "// New%s builds a %s backed by c with generated, zero-reflection\n", cs.StructName, cs.StructName)
+	fmt.Fprintf(buf, "// method implementations. See //go:generate feigngen.\n")
+	fmt.Fprintf(buf, "func New%s(c *feign.Client) *%s {\n", cs.StructName, cs.StructName)
+	fmt.Fprintf(buf, "\trawURL := %q\n", cs.BaseURL)
+	fmt.Fprintf(buf, "\tclient := &%s{}\n\n", cs.StructName)
+
+	for _, m := range cs.Methods {
+		writeMethod(buf, m)
+	}
+
+	buf.WriteString("\treturn client\n")
+	buf.WriteString("}\n\n")
+}
+
+func writeMethod(buf *bytes.Buffer, m methodSpec) {
+	paramList := make([]string, len(m.ParamNames))
+	for i, n := range m.ParamNames {
+		paramList[i] = fmt.Sprintf("%s %s", n, m.ParamTypes[i])
+	}
+	if m.HasCtx {
+		paramList = append([]string{"ctx context.Context"}, paramList...)
+	}
+
+	if m.Meta.Stream {
+		fmt.Fprintf(buf, "\tclient.%s = func(%s) (<-chan %s, <-chan error) {\n", m.Name, strings.Join(paramList, ", "), m.ResultType)
+	} else {
+		fmt.Fprintf(buf, "\tclient.%s = func(%s) (%s, error) {\n", m.Name, strings.Join(paramList, ", "), m.ResultType)
+	}
+	if !m.HasCtx {
+		buf.WriteString("\t\tctx := context.Background()\n")
+	}
+
+	if !m.Meta.Stream {
+		fmt.Fprintf(buf, "\t\tvar zero %s\n", m.ResultType)
+	}
+
+	buf.WriteString("\t\tbaseURL, endpointAddr, err := c.ResolveBaseURL(ctx, rawURL)\n")
+	buf.WriteString("\t\tif err != nil {\n")
+	if m.Meta.Stream {
+		fmt.Fprintf(buf, "\t\t\treturn feign.FailedStream[%s](err)\n", m.ResultType)
+	} else {
+		buf.WriteString("\t\t\treturn zero, err\n")
+	}
+	buf.WriteString("\t\t}\n")
+
+	idx := 0
+	var bodyVar string
+	var partVars []string
+	if m.Meta.Multipart {
+		for range m.Meta.Parts {
+			partVars = append(partVars, m.ParamNames[idx])
+			idx++
+		}
+	} else if m.Meta.BodyParam != "" {
+		bodyVar = m.ParamNames[idx]
+		idx++
+	}
+
+	buf.WriteString("\t\tpath := ")
+	fmt.Fprintf(buf, "%q\n", m.Meta.Path)
+	for _, p := range m.Meta.PathVars {
+		fmt.Fprintf(buf, "\t\tpath = strings.ReplaceAll(path, %q, fmt.Sprintf(\"%%v\", %s))\n", "{"+p+"}", m.ParamNames[idx])
+		idx++
+	}
+
+	buf.WriteString("\t\treq := &feign.Request{Method: ")
+	fmt.Fprintf(buf, "%q, Url: baseURL + path}\n", m.Meta.Method)
+	if len(m.Meta.Queries) > 0 {
+		buf.WriteString("\t\treq.Query = map[string]string{}\n")
+		for _, q := range m.Meta.Queries {
+			fmt.Fprintf(buf, "\t\treq.Query[%q] = fmt.Sprintf(\"%%v\", %s)\n", q, m.ParamNames[idx])
+			idx++
+		}
+	}
+	if len(m.Meta.Headers) > 0 || bodyVar != "" {
+		buf.WriteString("\t\treq.Headers = map[string]string{}\n")
+		for _, h := range m.Meta.Headers {
+			fmt.Fprintf(buf, "\t\treq.Headers[%q] = fmt.Sprintf(\"%%v\", %s)\n", h, m.ParamNames[idx])
+			idx++
+		}
+	}
+	if m.Meta.Multipart {
+		buf.WriteString("\t\treq.Multipart = true\n")
+		buf.WriteString("\t\treq.Parts = map[string]any{}\n")
+		for i, p := range m.Meta.Parts {
+			fmt.Fprintf(buf, "\t\treq.Parts[%q] = %s\n", p, partVars[i])
+		}
+	} else if bodyVar != "" {
+		fmt.Fprintf(buf, "\t\twriteCodec := c.ResolveWriteCodec(%q)\n", m.Meta.Consumes)
+		fmt.Fprintf(buf, "\t\tencodedBody, err := writeCodec.Marshal(%s)\n", bodyVar)
+		buf.WriteString("\t\tif err != nil {\n")
+		buf.WriteString("\t\t\treturn zero, fmt.Errorf(\"marshal failed: %w\", err)\n")
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t\treq.Body = encodedBody\n")
+		buf.WriteString("\t\treq.Headers[\"Content-Type\"] = writeCodec.ContentType()\n")
+	}
+
+	if m.Meta.Stream {
+		buf.WriteString("\t\t_ = endpointAddr // Stream bypasses DoDiscovered, so health feedback isn't reported for streamed calls.\n")
+		fmt.Fprintf(buf, "\t\treturn feign.Stream[%s](ctx, c, req)\n", m.ResultType)
+		buf.WriteString("\t}\n\n")
+		return
+	}
+
+	buf.WriteString("\t\tresp, err := c.DoDiscovered(ctx, req, endpointAddr)\n")
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\treturn zero, &feign.HttpError{StatusCode: 0, Status: \"connection error\", Body: err.Error()}\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tif resp.StatusCode < 200 || resp.StatusCode >= 300 {\n")
+	buf.WriteString("\t\t\treturn zero, c.DecodeHttpError(resp)\n")
+	buf.WriteString("\t\t}\n\n")
+	fmt.Fprintf(buf, "\t\treadCodec := c.ResolveReadCodec(resp.ContentType, %q)\n", m.Meta.Produces)
+
+	if strings.HasPrefix(m.ResultType, "*") {
+		elem := strings.TrimPrefix(m.ResultType, "*")
+		fmt.Fprintf(buf, "\t\tout := new(%s)\n", elem)
+		buf.WriteString("\t\tif err := readCodec.Unmarshal(resp.Body, out); err != nil {\n")
+		buf.WriteString("\t\t\treturn zero, fmt.Errorf(\"unmarshal failed: %w\", err)\n")
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t\treturn out, nil\n")
+	} else {
+		fmt.Fprintf(buf, "\t\tvar out %s\n", m.ResultType)
+		buf.WriteString("\t\tif err := readCodec.Unmarshal(resp.Body, &out); err != nil {\n")
+		buf.WriteString("\t\t\treturn zero, fmt.Errorf(\"unmarshal failed: %w\", err)\n")
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t\treturn out, nil\n")
+	}
+
+	buf.WriteString("\t}\n\n")
+}
@@ -21,6 +21,7 @@ type User struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+//go:generate go run github.com/kimxuanhong/go-feign/cmd/feigngen -dir .
 type UserClient struct {
 	_           struct{}                                                 `feign:"@Url http://localhost:8081/api/v1"`
 	GetUser     func(id string, auth string) (*User, error)              `feign:"@GET /users/{id} | @Path id | @Header Authorization"`
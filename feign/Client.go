@@ -2,46 +2,69 @@ package feign
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/go-resty/resty/v2"
 	"github.com/spf13/viper"
-	"go/ast"
-	"go/parser"
-	"go/token"
-	"log"
 	"reflect"
-	"runtime"
 	"strings"
+	"sync"
+	"time"
 )
 
 type MethodMeta struct {
 	Method    string
 	Path      string
+	Url       string
+	Stream    bool
 	PathVars  []string
 	Headers   []string
 	Queries   []string
 	BodyParam string
+	Consumes  string
+	Produces  string
+	Multipart bool
+	Parts     []string
 }
 
 type Client struct {
 	*resty.Client
-	baseURL string
-	headers map[string]string
-	Config  *Config
+	baseURL      string
+	headers      map[string]string
+	interceptors []Interceptor
+	codecs       map[string]Codec
+	Config       *Config
+
+	resolver     Resolver
+	loadBalancer LoadBalancer
+	coolOff      time.Duration
+	unhealthyMu  sync.Mutex
+	unhealthy    map[string]time.Time
+
+	errorDecoders map[int]func([]byte) error
 }
 
 func NewClient(configs ...*Config) *Client {
 	cfg := GetConfig(configs...)
+	rc := resty.New().
+		SetTimeout(cfg.Timeout).
+		SetRetryCount(cfg.RetryCount).
+		SetRetryWaitTime(cfg.RetryWait).
+		SetDebug(cfg.Debug)
+	for k, v := range cfg.Headers {
+		rc.SetHeader(k, v)
+	}
 	return &Client{
-		baseURL: cfg.Url,
-		headers: cfg.Headers,
-		Config:  cfg,
-		Client: resty.New().
-			SetTimeout(cfg.Timeout).
-			SetRetryCount(cfg.RetryCount).
-			SetRetryWaitTime(cfg.RetryWait).
-			SetDebug(cfg.Debug),
+		baseURL:      cfg.Url,
+		headers:      cfg.Headers,
+		Config:       cfg,
+		Client:       rc,
+		codecs:       defaultCodecs(),
+		resolver:     ConfigResolver{},
+		loadBalancer: NewRoundRobinBalancer(),
+		coolOff:      cfg.EndpointCoolOff,
+		unhealthy:    map[string]time.Time{},
+
+		errorDecoders: map[int]func([]byte) error{},
 	}
 }
 
@@ -55,7 +78,31 @@ func (e *HttpError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s - %s", e.StatusCode, e.Status, e.Body)
 }
 
-func resolveUrl(value string) string {
+// RegisterErrorDecoder maps a non-2xx status code to a decoder that turns
+// the response body into a domain-specific error, e.g. a 422 carrying a
+// validation payload into a *ValidationError callers can pattern-match with
+// errors.As. Statuses with no registered decoder fall back to *HttpError.
+func (c *Client) RegisterErrorDecoder(status int, decoder func([]byte) error) {
+	c.errorDecoders[status] = decoder
+}
+
+// DecodeHttpError turns a non-2xx *Response into an error, consulting the
+// decoder registered via RegisterErrorDecoder for resp.StatusCode before
+// falling back to a plain *HttpError.
+func (c *Client) DecodeHttpError(resp *Response) error {
+	if decoder, ok := c.errorDecoders[resp.StatusCode]; ok {
+		return decoder(resp.Body)
+	}
+	return &HttpError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(resp.Body)}
+}
+
+// ResolveUrl turns a `@Url` tag value into a concrete base URL without
+// service discovery: literal http(s) URLs pass through unchanged, anything
+// else is treated as a viper config key (e.g. "user.service.url") and
+// looked up. A "service://<name>" value needs a *Client's Resolver and
+// LoadBalancer to pick a live endpoint per call - use Client.ResolveBaseURL
+// for that, which feigngen-generated constructors call instead of this.
+func ResolveUrl(value string) string {
 	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
 		return value
 	}
@@ -70,14 +117,9 @@ func (c *Client) Create(target BaseClient) {
 	t := reflect.TypeOf(target).Elem()
 	v := reflect.ValueOf(target).Elem()
 
-	filePath, err := getFilePathOfStruct(target)
-	if err != nil {
-		log.Fatalf("failed to get file path of struct %T: %v", target, err)
-	}
-
-	metaMap, err := parseStructFuncTags(filePath, t.Name())
+	rawURL, err := structBaseURL(t)
 	if err != nil {
-		log.Fatalf("failed to parse tags for %T: %v", target, err)
+		panic(fmt.Sprintf("invalid feign tag on %s: %v", t.Name(), err))
 	}
 
 	for i := 0; i < t.NumField(); i++ {
@@ -95,21 +137,45 @@ func (c *Client) Create(target BaseClient) {
 		if !methodType.In(0).Implements(reflect.TypeOf((*context.Context)(nil)).Elem()) {
 			panic(fmt.Sprintf("method %s must take context.Context as first parameter", field.Name))
 		}
-		if methodType.NumOut() != 2 || !methodType.Out(1).Implements(reflect.TypeOf((*error)(nil)).Elem()) {
-			panic(fmt.Sprintf("method %s must return (T, error)", field.Name))
-		}
 
-		meta := metaMap[field.Name]
+		tagValue, ok := field.Tag.Lookup("feign")
+		if !ok {
+			panic(fmt.Sprintf("missing feign tag on %s", field.Name))
+		}
+		meta, err := ParseTag(tagValue)
+		if err != nil {
+			panic(fmt.Sprintf("invalid feign tag on %s: %v", field.Name, err))
+		}
 		if meta.Method == "" || meta.Path == "" {
 			panic(fmt.Sprintf("missing HTTP method or path in %s", field.Name))
 		}
 
+		if meta.Stream {
+			v.Field(i).Set(makeStreamFunc(c, field.Name, methodType, meta, rawURL))
+			continue
+		}
+
+		if methodType.NumOut() != 2 || !methodType.Out(1).Implements(reflect.TypeOf((*error)(nil)).Elem()) {
+			panic(fmt.Sprintf("method %s must return (T, error)", field.Name))
+		}
+
 		fn := reflect.MakeFunc(methodType, func(args []reflect.Value) []reflect.Value {
 			ctx := args[0].Interface().(context.Context)
 
+			baseURL, endpointAddr, err := c.ResolveBaseURL(ctx, rawURL)
+			if err != nil {
+				return []reflect.Value{reflect.Zero(methodType.Out(0)), reflect.ValueOf(err)}
+			}
+
 			j := 1
 			var body any
-			if meta.BodyParam != "" {
+			partsMap := map[string]any{}
+			if meta.Multipart {
+				for _, p := range meta.Parts {
+					partsMap[p] = args[j].Interface()
+					j++
+				}
+			} else if meta.BodyParam != "" {
 				body = args[j].Interface()
 				j++
 			}
@@ -132,36 +198,56 @@ func (c *Client) Create(target BaseClient) {
 				j++
 			}
 
-			r := c.R().SetContext(ctx)
-			for k, v := range c.headers {
-				r.SetHeader(k, v)
-			}
-			for k, v := range headersMap {
-				r.SetHeader(k, v)
+			var encodedBody []byte
+			if !meta.Multipart && body != nil {
+				writeCodec := c.ResolveWriteCodec(meta.Consumes)
+				encoded, merr := writeCodec.Marshal(body)
+				if merr != nil {
+					return []reflect.Value{reflect.Zero(methodType.Out(0)), reflect.ValueOf(fmt.Errorf("marshal failed: %w", merr))}
+				}
+				encodedBody = encoded
+				headersMap["Content-Type"] = writeCodec.ContentType()
 			}
-			if body != nil {
-				r.SetHeader("Content-Type", "application/json")
-				r.SetBody(body)
+
+			req := &Request{
+				Method:  meta.Method,
+				Url:     baseURL + pathProcessed,
+				Headers: headersMap,
+				Query:   queryParams,
 			}
-			if len(queryParams) > 0 {
-				r.SetQueryParams(queryParams)
+			if meta.Multipart {
+				req.Multipart = true
+				req.Parts = partsMap
+			} else if encodedBody != nil {
+				req.Body = encodedBody
 			}
 
-			resp, err := r.Execute(meta.Method, pathProcessed)
+			resp, err := c.DoDiscovered(ctx, req, endpointAddr)
 			if err != nil {
 				return []reflect.Value{reflect.Zero(methodType.Out(0)), reflect.ValueOf(&HttpError{0, "connection error", err.Error()})}
 			}
 
-			if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
-				return []reflect.Value{reflect.Zero(methodType.Out(0)), reflect.ValueOf(&HttpError{
-					StatusCode: resp.StatusCode(),
-					Status:     resp.Status(),
-					Body:       string(resp.Body()),
-				})}
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				return []reflect.Value{reflect.Zero(methodType.Out(0)), reflect.ValueOf(c.DecodeHttpError(resp))}
 			}
 
-			out := reflect.New(methodType.Out(0).Elem())
-			err = json.Unmarshal(resp.Body(), out.Interface())
+			// The result type isn't always a pointer (e.g. GetAllUser returns
+			// []User), so reflect.New can't always target Out(0).Elem()
+			// directly: for a pointer result it allocates the pointee and
+			// returns the pointer as-is, otherwise it allocates a pointer to
+			// the result type itself and dereferences it on the way out.
+			resultType := methodType.Out(0)
+			var target, out reflect.Value
+			if resultType.Kind() == reflect.Ptr {
+				target = reflect.New(resultType.Elem())
+				out = target
+			} else {
+				target = reflect.New(resultType)
+				out = target.Elem()
+			}
+
+			readCodec := c.ResolveReadCodec(resp.ContentType, meta.Produces)
+			err = readCodec.Unmarshal(resp.Body, target.Interface())
 			if err != nil {
 				return []reflect.Value{reflect.Zero(methodType.Out(0)), reflect.ValueOf(fmt.Errorf("unmarshal failed: %w", err))}
 			}
@@ -172,102 +258,27 @@ func (c *Client) Create(target BaseClient) {
 	}
 }
 
-func getFilePathOfStruct(i interface{}) (string, error) {
-	typ := reflect.TypeOf(i)
-	if typ.NumMethod() == 0 {
-		return "", fmt.Errorf("struct %T has no methods", i)
-	}
-	pc := typ.Method(0).Func.Pointer()
-	fn := runtime.FuncForPC(pc)
-	if fn == nil {
-		return "", fmt.Errorf("cannot find function for struct")
-	}
-	file, _ := fn.FileLine(pc)
-	return file, nil
-}
-
-// Refactored parser
-func parseStructFuncTags(filePath, structName string) (map[string]MethodMeta, error) {
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
-	if err != nil {
-		return nil, err
-	}
-
-	result := map[string]MethodMeta{}
-	for _, decl := range node.Decls {
-		genDecl, ok := decl.(*ast.GenDecl)
-		if !ok || genDecl.Tok != token.TYPE {
-			continue
-		}
-
-		for _, spec := range genDecl.Specs {
-			typeSpec, ok := spec.(*ast.TypeSpec)
-			if !ok || typeSpec.Name.Name != structName {
-				continue
-			}
-			structType, ok := typeSpec.Type.(*ast.StructType)
-			if !ok {
-				continue
-			}
-
-			for _, field := range structType.Fields.List {
-				_, ok := field.Type.(*ast.FuncType)
-				if !ok || len(field.Names) == 0 {
-					continue
-				}
-
-				methodName := field.Names[0].Name
-				meta := MethodMeta{}
-
-				if field.Doc != nil {
-					if err := parseComment(field.Doc.List, &meta); err != nil {
-						return nil, fmt.Errorf("invalid comment in %s: %w", methodName, err)
-					}
-				}
-				result[methodName] = meta
-			}
-		}
-	}
-	return result, nil
-}
-
-// Parse and validate tags
-func parseComment(comments []*ast.Comment, meta *MethodMeta) error {
-	seen := map[string]bool{}
-	for _, comment := range comments {
-		if !strings.HasPrefix(comment.Text, "// @") {
+// structBaseURL reads the `@Url` tag off target's conventional blank
+// "_ struct{}" field, the same place feigngen's findBaseURL looks, so
+// Client.Create resolves a base URL (including "service://<name>") instead
+// of issuing every call against a bare path.
+func structBaseURL(t reflect.Type) (string, error) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name != "_" {
 			continue
 		}
-		parts := strings.Fields(strings.TrimPrefix(comment.Text, "// "))
-		if len(parts) < 2 {
+		tagValue, ok := field.Tag.Lookup("feign")
+		if !ok {
 			continue
 		}
-		tag, value := strings.ToUpper(parts[0][1:]), parts[1]
-
-		if seen[tag] {
-			return fmt.Errorf("duplicate tag: @%s", tag)
+		meta, err := ParseTag(tagValue)
+		if err != nil {
+			return "", err
 		}
-		seen[tag] = true
-
-		switch tag {
-		case "GET", "POST", "PUT", "DELETE":
-			meta.Method = tag
-			meta.Path = value
-		case "PATH":
-			meta.PathVars = append(meta.PathVars, value)
-		case "QUERY":
-			meta.Queries = append(meta.Queries, value)
-		case "HEADER":
-			meta.Headers = append(meta.Headers, value)
-		case "BODY":
-			meta.BodyParam = value
-		default:
-			log.Printf("⚠️ Unknown tag @%s", tag)
+		if meta.Url != "" {
+			return meta.Url, nil
 		}
 	}
-	if meta.Method == "" || meta.Path == "" {
-		return fmt.Errorf("missing HTTP method or path")
-	}
-	return nil
+	return "", nil
 }
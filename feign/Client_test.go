@@ -0,0 +1,92 @@
+package feign
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type clientTestUser struct {
+	ID string `json:"id"`
+}
+
+type clientTestValidationError struct {
+	Field string
+}
+
+func (e *clientTestValidationError) Error() string { return "validation failed: " + e.Field }
+
+// clientTestClient's @Url points at the fixed address newClientTestServer
+// listens on - a struct tag can't embed a runtime-assigned port.
+type clientTestClient struct {
+	_          struct{}                                            `feign:"@Url http://127.0.0.1:18743"`
+	ListUsers  func(ctx context.Context) ([]clientTestUser, error) `feign:"@GET /users"`
+	CreateUser func(ctx context.Context) (clientTestUser, error)   `feign:"@POST /users/bad"`
+}
+
+func (c *clientTestClient) BaseUrl() string { return "" }
+
+func newClientTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:18743")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode([]clientTestUser{{ID: "1"}, {ID: "2"}})
+		case "/users/bad":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"field":"email"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	srv.Listener = ln
+	srv.Start()
+	return srv
+}
+
+// TestClientCreateEndToEnd exercises Client.Create's reflection fallback
+// against a real server: struct-tag parsing, base URL resolution via
+// ResolveBaseURL/DoDiscovered, non-pointer slice decoding, and a registered
+// error decoder all have to work together for this to pass.
+func TestClientCreateEndToEnd(t *testing.T) {
+	srv := newClientTestServer(t)
+	defer srv.Close()
+
+	c := NewClient()
+	c.RegisterErrorDecoder(http.StatusUnprocessableEntity, func(body []byte) error {
+		var payload struct {
+			Field string `json:"field"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return err
+		}
+		return &clientTestValidationError{Field: payload.Field}
+	})
+
+	client := &clientTestClient{}
+	c.Create(client)
+
+	users, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("ListUsers returned error: %v", err)
+	}
+	want := []clientTestUser{{ID: "1"}, {ID: "2"}}
+	if len(users) != len(want) || users[0] != want[0] || users[1] != want[1] {
+		t.Errorf("ListUsers() = %+v, want %+v", users, want)
+	}
+
+	_, err = client.CreateUser(context.Background())
+	var verr *clientTestValidationError
+	if !errors.As(err, &verr) || verr.Field != "email" {
+		t.Fatalf("CreateUser error = %v, want *clientTestValidationError{Field: \"email\"}", err)
+	}
+}
@@ -0,0 +1,189 @@
+package feign
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Codec marshals and unmarshals request/response bodies for one MIME type.
+// Register one with Client.RegisterCodec to support a content type the
+// built-ins don't cover, or to override a built-in's behavior.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+	ContentType() string
+}
+
+// Built-in codecs, registered on every *Client by default. Each is also
+// exported so it can be passed to RegisterCodec to restore default behavior
+// after an override, or used directly.
+var (
+	JSONCodec     Codec = jsonCodec{}
+	XMLCodec      Codec = xmlCodec{}
+	FormCodec     Codec = formCodec{}
+	ProtobufCodec Codec = protobufCodec{}
+)
+
+func defaultCodecs() map[string]Codec {
+	return map[string]Codec{
+		JSONCodec.ContentType():     JSONCodec,
+		XMLCodec.ContentType():      XMLCodec,
+		FormCodec.ContentType():     FormCodec,
+		ProtobufCodec.ContentType(): ProtobufCodec,
+	}
+}
+
+// RegisterCodec adds codec to the client's registry, keyed by
+// codec.ContentType(). Registering a codec for a content type that already
+// has one (built-in or otherwise) replaces it.
+func (c *Client) RegisterCodec(codec Codec) {
+	c.codecs[codec.ContentType()] = codec
+}
+
+// Codec returns the codec registered for contentType, or nil if none is
+// registered. A "; charset=..." (or any other parameter) suffix on
+// contentType is ignored.
+func (c *Client) Codec(contentType string) Codec {
+	return c.codecs[baseMimeType(contentType)]
+}
+
+// ResolveWriteCodec picks the codec a request body should be marshaled
+// with: the codec registered for the method's `@Consumes` tag, or
+// JSONCodec if consumes is empty or unregistered.
+func (c *Client) ResolveWriteCodec(consumes string) Codec {
+	if consumes != "" {
+		if codec := c.Codec(consumes); codec != nil {
+			return codec
+		}
+	}
+	return JSONCodec
+}
+
+// ResolveReadCodec picks the codec a response body should be unmarshaled
+// with: the codec registered for the response's actual Content-Type,
+// falling back to the method's `@Produces` tag, falling back to JSONCodec.
+func (c *Client) ResolveReadCodec(responseContentType, produces string) Codec {
+	if codec := c.Codec(responseContentType); codec != nil {
+		return codec
+	}
+	if produces != "" {
+		if codec := c.Codec(produces); codec != nil {
+			return codec
+		}
+	}
+	return JSONCodec
+}
+
+func baseMimeType(contentType string) string {
+	mime, _, _ := strings.Cut(contentType, ";")
+	return strings.TrimSpace(mime)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) ContentType() string                { return "application/json" }
+
+type xmlCodec struct{}
+
+func (xmlCodec) Marshal(v any) ([]byte, error)      { return xml.Marshal(v) }
+func (xmlCodec) Unmarshal(data []byte, v any) error { return xml.Unmarshal(data, v) }
+func (xmlCodec) ContentType() string                { return "application/xml" }
+
+// formCodec marshals a struct (or map[string]string) into
+// application/x-www-form-urlencoded, using a field's `form` tag, falling
+// back to its `json` tag, falling back to its Go name.
+type formCodec struct{}
+
+func (formCodec) ContentType() string { return "application/x-www-form-urlencoded" }
+
+func (formCodec) Marshal(v any) ([]byte, error) {
+	values, err := formValues(v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(values.Encode()), nil
+}
+
+func (formCodec) Unmarshal(data []byte, v any) error {
+	out, ok := v.(*map[string]string)
+	if !ok {
+		return fmt.Errorf("feign: form codec can only decode into *map[string]string, got %T", v)
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+	if *out == nil {
+		*out = map[string]string{}
+	}
+	for k := range values {
+		(*out)[k] = values.Get(k)
+	}
+	return nil
+}
+
+func formValues(v any) (url.Values, error) {
+	if m, ok := v.(map[string]string); ok {
+		values := url.Values{}
+		for k, val := range m {
+			values.Set(k, val)
+		}
+		return values, nil
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("feign: form codec can only encode a struct or map[string]string, got %T", v)
+	}
+
+	values := url.Values{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Get("form")
+		if name == "" {
+			name, _, _ = strings.Cut(field.Tag.Get("json"), ",")
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if name == "-" {
+			continue
+		}
+		values.Set(name, fmt.Sprintf("%v", rv.Field(i).Interface()))
+	}
+	return values, nil
+}
+
+// protobufCodec defers to the value's own Marshal/Unmarshal methods (the
+// shape generated protobuf messages expose) rather than importing a
+// protobuf runtime. Pass a message generated by protoc-gen-go or
+// gogo/protobuf; anything else fails with a clear error.
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string { return "application/protobuf" }
+
+func (protobufCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(interface{ Marshal() ([]byte, error) })
+	if !ok {
+		return nil, fmt.Errorf("feign: protobuf codec requires a type with Marshal() ([]byte, error), got %T", v)
+	}
+	return m.Marshal()
+}
+
+func (protobufCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(interface{ Unmarshal([]byte) error })
+	if !ok {
+		return fmt.Errorf("feign: protobuf codec requires a type with Unmarshal([]byte) error, got %T", v)
+	}
+	return m.Unmarshal(data)
+}
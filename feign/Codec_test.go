@@ -0,0 +1,38 @@
+package feign
+
+import "testing"
+
+func TestResolveWriteCodecFallsBackToJSON(t *testing.T) {
+	c := NewClient()
+
+	if codec := c.ResolveWriteCodec(""); codec != JSONCodec {
+		t.Errorf("ResolveWriteCodec(\"\") = %v, want JSONCodec", codec)
+	}
+	if codec := c.ResolveWriteCodec("application/does-not-exist"); codec != JSONCodec {
+		t.Errorf("ResolveWriteCodec(unregistered) = %v, want JSONCodec", codec)
+	}
+	if codec := c.ResolveWriteCodec("application/xml"); codec != XMLCodec {
+		t.Errorf("ResolveWriteCodec(\"application/xml\") = %v, want XMLCodec", codec)
+	}
+}
+
+func TestResolveReadCodecPrefersResponseContentType(t *testing.T) {
+	c := NewClient()
+
+	// Response Content-Type wins over @Produces when both are registered.
+	if codec := c.ResolveReadCodec("application/xml", "application/json"); codec != XMLCodec {
+		t.Errorf("ResolveReadCodec(xml, json) = %v, want XMLCodec", codec)
+	}
+	// Falls back to @Produces when the response Content-Type isn't registered.
+	if codec := c.ResolveReadCodec("text/plain; charset=utf-8", "application/xml"); codec != XMLCodec {
+		t.Errorf("ResolveReadCodec(unregistered, xml) = %v, want XMLCodec", codec)
+	}
+	// Falls back to JSON when neither is registered.
+	if codec := c.ResolveReadCodec("", ""); codec != JSONCodec {
+		t.Errorf("ResolveReadCodec(\"\", \"\") = %v, want JSONCodec", codec)
+	}
+	// A "; charset=..." suffix on the response Content-Type is ignored.
+	if codec := c.ResolveReadCodec("application/json; charset=utf-8", ""); codec != JSONCodec {
+		t.Errorf("ResolveReadCodec(json with charset, \"\") = %v, want JSONCodec", codec)
+	}
+}
@@ -8,12 +8,13 @@ import (
 )
 
 type Config struct {
-	Url        string            `mapstructure:"url" yaml:"url"`
-	Timeout    time.Duration     `mapstructure:"timeout" yaml:"timeout"`
-	RetryCount int               `mapstructure:"retry_count" yaml:"retry_count"`
-	RetryWait  time.Duration     `mapstructure:"retry_wait" yaml:"retry_wait"`
-	Headers    map[string]string `mapstructure:"headers" yaml:"headers"`
-	Debug      bool              `mapstructure:"debug" yaml:"debug"`
+	Url             string            `mapstructure:"url" yaml:"url"`
+	Timeout         time.Duration     `mapstructure:"timeout" yaml:"timeout"`
+	RetryCount      int               `mapstructure:"retry_count" yaml:"retry_count"`
+	RetryWait       time.Duration     `mapstructure:"retry_wait" yaml:"retry_wait"`
+	Headers         map[string]string `mapstructure:"headers" yaml:"headers"`
+	Debug           bool              `mapstructure:"debug" yaml:"debug"`
+	EndpointCoolOff time.Duration     `mapstructure:"endpoint_cool_off" yaml:"endpoint_cool_off"`
 }
 
 func NewConfig() *Config {
@@ -21,11 +22,13 @@ func NewConfig() *Config {
 	retryWait, _ := time.ParseDuration(getEnv("FEIGN_RETRY_WAIT", "1s"))
 	retryCount, _ := strconv.Atoi(getEnv("FEIGN_RETRY_COUNT", "0"))
 	debug, _ := strconv.ParseBool(getEnv("FEIGN_DEBUG", "false"))
+	endpointCoolOff, _ := time.ParseDuration(getEnv("FEIGN_ENDPOINT_COOL_OFF", "30s"))
 	return &Config{
-		Timeout:    timeout,
-		RetryCount: retryCount,
-		RetryWait:  retryWait,
-		Debug:      debug,
+		Timeout:         timeout,
+		RetryCount:      retryCount,
+		RetryWait:       retryWait,
+		Debug:           debug,
+		EndpointCoolOff: endpointCoolOff,
 	}
 }
 
@@ -45,10 +48,12 @@ func GetConfig(configs ...*Config) *Config {
 	viper.SetDefault("feign.retry_count", "0")
 	viper.SetDefault("feign.retry_wait", "1s")
 	viper.SetDefault("feign.debug", false)
+	viper.SetDefault("feign.endpoint_cool_off", "30s")
 	return &Config{
-		Timeout:    viper.GetDuration("feign.timeout"),
-		RetryCount: viper.GetInt("feign.retry_count"),
-		RetryWait:  viper.GetDuration("feign.retry_wait"),
-		Debug:      viper.GetBool("feign.debug"),
+		Timeout:         viper.GetDuration("feign.timeout"),
+		RetryCount:      viper.GetInt("feign.retry_count"),
+		RetryWait:       viper.GetDuration("feign.retry_wait"),
+		Debug:           viper.GetBool("feign.debug"),
+		EndpointCoolOff: viper.GetDuration("feign.endpoint_cool_off"),
 	}
 }
@@ -0,0 +1,58 @@
+package feign
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ConsulResolver resolves a service name to its passing (healthy) instances
+// via the Consul HTTP API's health endpoint directly.
+type ConsulResolver struct {
+	// Address is the Consul agent base URL, e.g. "http://localhost:8500".
+	Address string
+	// HTTPClient defaults to http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+type consulHealthEntry struct {
+	Service struct {
+		Address string
+		Port    int
+	} `json:"Service"`
+}
+
+func (r ConsulResolver) Resolve(ctx context.Context, name string) ([]Endpoint, error) {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	url := fmt.Sprintf("%s/v1/health/service/%s?passing=true", strings.TrimSuffix(r.Address, "/"), name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("feign: consul health query for %q: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feign: consul health query for %q: status %s", name, resp.Status)
+	}
+
+	var entries []consulHealthEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("feign: decode consul response for %q: %w", name, err)
+	}
+
+	endpoints := make([]Endpoint, 0, len(entries))
+	for _, entry := range entries {
+		endpoints = append(endpoints, Endpoint{Host: entry.Service.Address, Port: entry.Service.Port})
+	}
+	return endpoints, nil
+}
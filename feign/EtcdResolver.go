@@ -0,0 +1,97 @@
+package feign
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// EtcdResolver resolves a service name to the endpoints registered under an
+// etcd key prefix, via etcd's v3 JSON gRPC-gateway. Each key under
+// "<Prefix>/<name>/" is expected to hold one endpoint's "host:port" value.
+type EtcdResolver struct {
+	// Address is the etcd gRPC-gateway base URL, e.g. "http://localhost:2379".
+	Address string
+	// Prefix defaults to "/services" when empty.
+	Prefix string
+	// HTTPClient defaults to http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+type etcdRangeResponse struct {
+	Kvs []struct {
+		Value string `json:"value"`
+	} `json:"kvs"`
+}
+
+func (r EtcdResolver) Resolve(ctx context.Context, name string) ([]Endpoint, error) {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	prefix := r.Prefix
+	if prefix == "" {
+		prefix = "/services"
+	}
+	key := fmt.Sprintf("%s/%s/", strings.TrimSuffix(prefix, "/"), name)
+
+	body, err := json.Marshal(map[string]string{
+		"key":       base64.StdEncoding.EncodeToString([]byte(key)),
+		"range_end": base64.StdEncoding.EncodeToString(prefixRangeEnd(key)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(r.Address, "/")+"/v3/kv/range", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("feign: etcd range query for %q: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feign: etcd range query for %q: status %s", name, resp.Status)
+	}
+
+	var rangeResp etcdRangeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rangeResp); err != nil {
+		return nil, fmt.Errorf("feign: decode etcd response for %q: %w", name, err)
+	}
+
+	endpoints := make([]Endpoint, 0, len(rangeResp.Kvs))
+	for _, kv := range rangeResp.Kvs {
+		raw, err := base64.StdEncoding.DecodeString(kv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("feign: decode etcd value for %q: %w", name, err)
+		}
+		endpoint, err := parseEndpoint(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// prefixRangeEnd computes etcd's conventional "end of prefix range" key: the
+// prefix with its last byte incremented, the same trick etcdctl uses under
+// the hood for `--prefix` queries.
+func prefixRangeEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return []byte{0}
+}
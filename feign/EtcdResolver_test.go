@@ -0,0 +1,28 @@
+package feign
+
+import "testing"
+
+func TestPrefixRangeEnd(t *testing.T) {
+	cases := []struct {
+		prefix string
+		want   string
+	}{
+		{"/services/user/", "/services/user0"},
+		{"a", "b"},
+	}
+
+	for _, c := range cases {
+		got := string(prefixRangeEnd(c.prefix))
+		if got != c.want {
+			t.Errorf("prefixRangeEnd(%q) = %q, want %q", c.prefix, got, c.want)
+		}
+	}
+}
+
+func TestPrefixRangeEndAllFF(t *testing.T) {
+	got := prefixRangeEnd(string([]byte{0xff, 0xff}))
+	want := []byte{0}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("prefixRangeEnd(0xffff) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+package feign
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Request is the transport-agnostic view of an outgoing call that an
+// Interceptor sees and may rewrite before it reaches the wire.
+type Request struct {
+	Method  string
+	Url     string
+	Headers map[string]string
+	Query   map[string]string
+	Body    any
+
+	// Multipart marks this request as a multipart/form-data upload. When
+	// true, transport builds the request from Parts instead of Body.
+	Multipart bool
+	// Parts holds one multipart/form-data field per entry, keyed by field
+	// name. A FilePart, io.Reader, *os.File, or []byte value is sent as a
+	// file field; a struct or map is JSON-encoded into a plain form field;
+	// anything else is formatted with fmt.Sprintf("%v", ...) into one.
+	Parts map[string]any
+}
+
+// FilePart is a multipart file field with explicit metadata. Pass one as a
+// @Multipart method's @Part argument when the wire field name, filename, or
+// content type resty would otherwise infer isn't right; for the common
+// case, pass an io.Reader, *os.File, or []byte directly instead. Name
+// overrides the multipart field name (the @Part tag's parameter name is
+// used when Name is empty).
+type FilePart struct {
+	Name        string
+	Filename    string
+	ContentType string
+	Reader      io.Reader
+}
+
+// Response is the transport-agnostic view of a completed call. It is
+// returned even for non-2xx status codes so interceptors such as Retry can
+// inspect the status before the caller turns it into an *HttpError.
+type Response struct {
+	StatusCode  int
+	Status      string
+	Body        []byte
+	ContentType string
+}
+
+// Invoker performs (or forwards) a single call.
+type Invoker func(ctx context.Context, req *Request) (*Response, error)
+
+// Interceptor wraps an Invoker with cross-cutting behavior: auth, logging,
+// tracing, metrics, circuit breaking, retries, and the like. Interceptors
+// compose like HTTP middleware - each one decides whether, and how, to call
+// next.
+type Interceptor func(next Invoker) Invoker
+
+// Use appends interceptors to the client's chain. Interceptors run in the
+// order they are registered: the first one registered is the outermost and
+// sees the request first.
+func (c *Client) Use(interceptors ...Interceptor) {
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+// Do runs req through the registered interceptor chain and the underlying
+// resty transport. Generated clients and Client.Create's reflection fallback
+// both call this instead of talking to resty directly.
+func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
+	invoke := c.transport
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		invoke = c.interceptors[i](invoke)
+	}
+	return invoke(ctx, req)
+}
+
+// transport is the innermost Invoker: it actually talks to resty.
+func (c *Client) transport(ctx context.Context, req *Request) (*Response, error) {
+	r := c.R().SetContext(ctx)
+	for k, v := range req.Headers {
+		r.SetHeader(k, v)
+	}
+	if len(req.Query) > 0 {
+		r.SetQueryParams(req.Query)
+	}
+	if req.Multipart {
+		if err := setMultipartParts(r, req.Parts); err != nil {
+			return nil, err
+		}
+	} else if req.Body != nil {
+		r.SetBody(req.Body)
+	}
+
+	resp, err := r.Execute(req.Method, req.Url)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		StatusCode:  resp.StatusCode(),
+		Status:      resp.Status(),
+		Body:        resp.Body(),
+		ContentType: resp.Header().Get("Content-Type"),
+	}, nil
+}
+
+// setMultipartParts attaches parts to r as multipart/form-data fields. A
+// FilePart, io.Reader, *os.File, or []byte value becomes a file field (any
+// of these present makes resty send the whole request as multipart); a
+// struct or map becomes a JSON-encoded form field; anything else becomes a
+// form field via fmt.Sprintf("%v", ...).
+func setMultipartParts(r *resty.Request, parts map[string]any) error {
+	for name, value := range parts {
+		switch v := value.(type) {
+		case FilePart:
+			fieldName := v.Name
+			if fieldName == "" {
+				fieldName = name
+			}
+			if v.ContentType != "" {
+				r.SetMultipartField(fieldName, v.Filename, v.ContentType, v.Reader)
+			} else {
+				r.SetFileReader(fieldName, v.Filename, v.Reader)
+			}
+		case *os.File:
+			r.SetFileReader(name, filepath.Base(v.Name()), v)
+		case io.Reader:
+			r.SetFileReader(name, name, v)
+		case []byte:
+			r.SetFileReader(name, name, bytes.NewReader(v))
+		default:
+			field, err := formatMultipartField(v)
+			if err != nil {
+				return fmt.Errorf("feign: multipart field %q: %w", name, err)
+			}
+			r.SetFormData(map[string]string{name: field})
+		}
+	}
+	return nil
+}
+
+// formatMultipartField renders a non-file @Part value as plain text for a
+// multipart form field: JSON for a struct or map, fmt's default formatting
+// for everything else (matching how path/query/header params are rendered).
+func formatMultipartField(v any) (string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() == reflect.Struct || rv.Kind() == reflect.Map {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+	return fmt.Sprintf("%v", v), nil
+}
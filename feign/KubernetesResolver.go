@@ -0,0 +1,29 @@
+package feign
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// KubernetesResolver resolves a service name to its endpoints via a
+// headless Kubernetes service's DNS-SRV records - the same mechanism
+// `nslookup -type=SRV <name>` uses inside a cluster.
+type KubernetesResolver struct{}
+
+// Resolve treats name as the full SRV record name Kubernetes registers for
+// a headless service's named port, e.g.
+// "_http._tcp.user-service.default.svc.cluster.local".
+func (KubernetesResolver) Resolve(ctx context.Context, name string) ([]Endpoint, error) {
+	_, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, fmt.Errorf("feign: lookup SRV for %q: %w", name, err)
+	}
+
+	endpoints := make([]Endpoint, 0, len(addrs))
+	for _, addr := range addrs {
+		endpoints = append(endpoints, Endpoint{Host: strings.TrimSuffix(addr.Target, "."), Port: int(addr.Port)})
+	}
+	return endpoints, nil
+}
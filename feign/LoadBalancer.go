@@ -0,0 +1,96 @@
+package feign
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LoadBalancer picks one endpoint out of a Resolver's result for a single
+// call. Client holds one LoadBalancer, shared across every service-discovery
+// call; set a different one with Client.UseLoadBalancer.
+type LoadBalancer interface {
+	Pick(endpoints []Endpoint) (Endpoint, error)
+}
+
+// RoundRobinBalancer cycles through endpoints in order.
+type RoundRobinBalancer struct {
+	counter uint64
+}
+
+func NewRoundRobinBalancer() *RoundRobinBalancer {
+	return &RoundRobinBalancer{}
+}
+
+func (b *RoundRobinBalancer) Pick(endpoints []Endpoint) (Endpoint, error) {
+	if len(endpoints) == 0 {
+		return Endpoint{}, fmt.Errorf("feign: no endpoints to pick from")
+	}
+	n := atomic.AddUint64(&b.counter, 1)
+	return endpoints[(n-1)%uint64(len(endpoints))], nil
+}
+
+// RandomBalancer picks a uniformly random endpoint.
+type RandomBalancer struct{}
+
+func (RandomBalancer) Pick(endpoints []Endpoint) (Endpoint, error) {
+	if len(endpoints) == 0 {
+		return Endpoint{}, fmt.Errorf("feign: no endpoints to pick from")
+	}
+	return endpoints[rand.Intn(len(endpoints))], nil
+}
+
+// P2CEWMABalancer implements power-of-two-choices: each Pick samples two
+// random endpoints and returns the one with the lower exponentially-weighted
+// moving average latency, treating endpoints it has no sample for yet as
+// having zero latency so every endpoint gets tried at least once. Feed it
+// samples with Report; Client.DoDiscovered does this automatically.
+type P2CEWMABalancer struct {
+	mu    sync.Mutex
+	ewma  map[string]time.Duration
+	decay float64
+}
+
+// NewP2CEWMABalancer returns a P2CEWMABalancer with the default decay
+// (weight given to each new sample relative to the running average).
+func NewP2CEWMABalancer() *P2CEWMABalancer {
+	return &P2CEWMABalancer{ewma: map[string]time.Duration{}, decay: 0.1}
+}
+
+func (b *P2CEWMABalancer) Pick(endpoints []Endpoint) (Endpoint, error) {
+	if len(endpoints) == 0 {
+		return Endpoint{}, fmt.Errorf("feign: no endpoints to pick from")
+	}
+	if len(endpoints) == 1 {
+		return endpoints[0], nil
+	}
+
+	i := rand.Intn(len(endpoints))
+	j := rand.Intn(len(endpoints) - 1)
+	if j >= i {
+		j++
+	}
+	a, c := endpoints[i], endpoints[j]
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ewma[a.Address()] <= b.ewma[c.Address()] {
+		return a, nil
+	}
+	return c, nil
+}
+
+// Report feeds back the latency observed for an endpoint so future Pick
+// calls can favor faster ones.
+func (b *P2CEWMABalancer) Report(addr string, latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	prev, ok := b.ewma[addr]
+	if !ok {
+		b.ewma[addr] = latency
+		return
+	}
+	b.ewma[addr] = time.Duration(float64(prev)*(1-b.decay) + float64(latency)*b.decay)
+}
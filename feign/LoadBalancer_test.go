@@ -0,0 +1,48 @@
+package feign
+
+import (
+	"testing"
+	"time"
+)
+
+func TestP2CEWMABalancerPickSingleEndpoint(t *testing.T) {
+	b := NewP2CEWMABalancer()
+	endpoints := []Endpoint{{Host: "a", Port: 1}}
+
+	picked, err := b.Pick(endpoints)
+	if err != nil {
+		t.Fatalf("Pick returned error: %v", err)
+	}
+	if picked != endpoints[0] {
+		t.Errorf("Pick() = %v, want %v", picked, endpoints[0])
+	}
+}
+
+func TestP2CEWMABalancerPickNoEndpoints(t *testing.T) {
+	b := NewP2CEWMABalancer()
+	if _, err := b.Pick(nil); err == nil {
+		t.Error("Pick with no endpoints should return an error")
+	}
+}
+
+func TestP2CEWMABalancerPrefersFasterEndpoint(t *testing.T) {
+	b := NewP2CEWMABalancer()
+	fast := Endpoint{Host: "fast", Port: 1}
+	slow := Endpoint{Host: "slow", Port: 2}
+
+	for i := 0; i < 20; i++ {
+		b.Report(fast.Address(), time.Millisecond)
+		b.Report(slow.Address(), 100*time.Millisecond)
+	}
+
+	endpoints := []Endpoint{fast, slow}
+	for i := 0; i < 20; i++ {
+		picked, err := b.Pick(endpoints)
+		if err != nil {
+			t.Fatalf("Pick returned error: %v", err)
+		}
+		if picked != fast {
+			t.Errorf("Pick() = %v, want %v (the endpoint with lower reported latency)", picked, fast)
+		}
+	}
+}
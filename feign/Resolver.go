@@ -0,0 +1,187 @@
+package feign
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Endpoint is one live instance of a service, as returned by a Resolver.
+type Endpoint struct {
+	Host string
+	Port int
+}
+
+// Address formats e as the "host:port" pair a LoadBalancer picks between
+// and ResolveBaseURL turns into a URL.
+func (e Endpoint) Address() string {
+	return fmt.Sprintf("%s:%d", e.Host, e.Port)
+}
+
+func parseEndpoint(hostport string) (Endpoint, error) {
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("feign: invalid endpoint %q: %w", hostport, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("feign: invalid endpoint port %q: %w", hostport, err)
+	}
+	return Endpoint{Host: host, Port: port}, nil
+}
+
+// Resolver looks up the live endpoints behind a logical service name, the
+// way `@Url service://<name>` asks for. Register one with Client.UseResolver;
+// ConfigResolver is what every *Client uses until overridden.
+type Resolver interface {
+	Resolve(ctx context.Context, name string) ([]Endpoint, error)
+}
+
+// StaticResolver resolves from an in-memory map, for tests and callers that
+// already know their endpoints and don't need Consul/etcd/Kubernetes.
+type StaticResolver map[string][]Endpoint
+
+func (r StaticResolver) Resolve(_ context.Context, name string) ([]Endpoint, error) {
+	endpoints, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("feign: no endpoints registered for %q", name)
+	}
+	return endpoints, nil
+}
+
+// ConfigResolver resolves a service name to the endpoints listed under the
+// viper key "<name>.endpoints", a comma-separated list of "host:port"
+// pairs. It is the default Resolver on every *Client - the zero-dependency
+// option for a static, config-driven endpoint list.
+type ConfigResolver struct{}
+
+func (ConfigResolver) Resolve(_ context.Context, name string) ([]Endpoint, error) {
+	raw := viper.GetString(name + ".endpoints")
+	if raw == "" {
+		return nil, fmt.Errorf("feign: no endpoints configured for %q (expected viper key %q)", name, name+".endpoints")
+	}
+
+	var endpoints []Endpoint
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		endpoint, err := parseEndpoint(part)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// UseResolver overrides the Resolver used to resolve `service://` endpoints.
+// The default is ConfigResolver.
+func (c *Client) UseResolver(r Resolver) {
+	c.resolver = r
+}
+
+// UseLoadBalancer overrides the LoadBalancer used to pick between a
+// resolved service's endpoints. The default is a RoundRobinBalancer.
+func (c *Client) UseLoadBalancer(lb LoadBalancer) {
+	c.loadBalancer = lb
+}
+
+// MarkUnhealthy excludes addr from endpoint selection for the client's
+// configured cool-off window (Config.EndpointCoolOff), so the next call to
+// the same service picks a different endpoint. DoDiscovered calls this
+// automatically on a connection-level failure.
+func (c *Client) MarkUnhealthy(addr string) {
+	c.unhealthyMu.Lock()
+	defer c.unhealthyMu.Unlock()
+	c.unhealthy[addr] = time.Now().Add(c.coolOff)
+}
+
+func (c *Client) isHealthy(addr string) bool {
+	c.unhealthyMu.Lock()
+	defer c.unhealthyMu.Unlock()
+	until, ok := c.unhealthy[addr]
+	if !ok {
+		return true
+	}
+	if time.Now().After(until) {
+		delete(c.unhealthy, addr)
+		return true
+	}
+	return false
+}
+
+// ResolveBaseURL turns a `@Url` tag value into a concrete base URL for a
+// single call. Literal http(s) URLs pass through unchanged, exactly like
+// ResolveUrl. "service://<name>" is resolved via the client's Resolver and
+// narrowed to one endpoint by its LoadBalancer, skipping any endpoint that
+// MarkUnhealthy last cooled off (unless every endpoint is cooling off, in
+// which case it tries the full set rather than failing outright). Anything
+// else is treated as a viper config key, as ResolveUrl is for the static
+// case. The returned endpointAddr is "" unless service discovery picked an
+// endpoint; pass it to DoDiscovered so a failure can cool that endpoint off.
+func (c *Client) ResolveBaseURL(ctx context.Context, raw string) (baseURL string, endpointAddr string, err error) {
+	if strings.HasPrefix(raw, "http://") || strings.HasPrefix(raw, "https://") {
+		return raw, "", nil
+	}
+
+	name, ok := strings.CutPrefix(raw, "service://")
+	if !ok {
+		return viper.GetString(raw), "", nil
+	}
+
+	endpoints, err := c.resolver.Resolve(ctx, name)
+	if err != nil {
+		return "", "", fmt.Errorf("feign: resolve %q: %w", name, err)
+	}
+	if len(endpoints) == 0 {
+		return "", "", fmt.Errorf("feign: resolver returned no endpoints for %q", name)
+	}
+
+	healthy := make([]Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if c.isHealthy(endpoint.Address()) {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	if len(healthy) == 0 {
+		healthy = endpoints
+	}
+
+	picked, err := c.loadBalancer.Pick(healthy)
+	if err != nil {
+		return "", "", fmt.Errorf("feign: pick endpoint for %q: %w", name, err)
+	}
+	return "http://" + picked.Address(), picked.Address(), nil
+}
+
+// DoDiscovered wraps Do for a request built against a service-discovered
+// endpoint: it times the call, reports the latency to the configured
+// LoadBalancer when it implements Report(string, time.Duration), and marks
+// endpointAddr unhealthy on a connection-level failure so the next call
+// picks a different endpoint. endpointAddr should be "" for requests that
+// didn't go through service discovery, in which case DoDiscovered behaves
+// exactly like Do.
+func (c *Client) DoDiscovered(ctx context.Context, req *Request, endpointAddr string) (*Response, error) {
+	if endpointAddr == "" {
+		return c.Do(ctx, req)
+	}
+
+	start := time.Now()
+	resp, err := c.Do(ctx, req)
+	if reporter, ok := c.loadBalancer.(interface {
+		Report(addr string, latency time.Duration)
+	}); ok {
+		reporter.Report(endpointAddr, time.Since(start))
+	}
+	if err != nil {
+		c.MarkUnhealthy(endpointAddr)
+	}
+	return resp, err
+}
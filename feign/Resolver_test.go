@@ -0,0 +1,144 @@
+package feign
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func mustEndpoint(t *testing.T, rawURL string) Endpoint {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("parse %q: %v", rawURL, err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("parse port of %q: %v", rawURL, err)
+	}
+	return Endpoint{Host: u.Hostname(), Port: port}
+}
+
+func TestResolveBaseURLNormalPick(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	endpoint := mustEndpoint(t, srv.URL)
+
+	c := NewClient()
+	c.UseResolver(StaticResolver{"users": {endpoint}})
+
+	baseURL, addr, err := c.ResolveBaseURL(context.Background(), "service://users")
+	if err != nil {
+		t.Fatalf("ResolveBaseURL returned error: %v", err)
+	}
+	if baseURL != "http://"+endpoint.Address() {
+		t.Errorf("baseURL = %q, want %q", baseURL, "http://"+endpoint.Address())
+	}
+	if addr != endpoint.Address() {
+		t.Errorf("endpointAddr = %q, want %q", addr, endpoint.Address())
+	}
+}
+
+func TestResolveBaseURLSkipsUnhealthyEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	healthy := mustEndpoint(t, srv.URL)
+	unhealthy := Endpoint{Host: "127.0.0.1", Port: 1}
+
+	c := NewClient()
+	c.UseResolver(StaticResolver{"users": {healthy, unhealthy}})
+	c.MarkUnhealthy(unhealthy.Address())
+
+	for i := 0; i < 5; i++ {
+		_, addr, err := c.ResolveBaseURL(context.Background(), "service://users")
+		if err != nil {
+			t.Fatalf("ResolveBaseURL returned error: %v", err)
+		}
+		if addr != healthy.Address() {
+			t.Errorf("ResolveBaseURL picked %q, want it to always skip the unhealthy endpoint %q", addr, unhealthy.Address())
+		}
+	}
+}
+
+func TestResolveBaseURLAllUnhealthyFallsBackToFullSet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	endpoint := mustEndpoint(t, srv.URL)
+
+	c := NewClient()
+	c.UseResolver(StaticResolver{"users": {endpoint}})
+	c.MarkUnhealthy(endpoint.Address())
+
+	// The only endpoint is unhealthy, so ResolveBaseURL must fall back to
+	// the full (unfiltered) set rather than failing outright.
+	_, addr, err := c.ResolveBaseURL(context.Background(), "service://users")
+	if err != nil {
+		t.Fatalf("ResolveBaseURL returned error: %v", err)
+	}
+	if addr != endpoint.Address() {
+		t.Errorf("addr = %q, want %q", addr, endpoint.Address())
+	}
+}
+
+type recordingBalancer struct {
+	reports map[string]time.Duration
+}
+
+func (b *recordingBalancer) Pick(endpoints []Endpoint) (Endpoint, error) {
+	return endpoints[0], nil
+}
+
+func (b *recordingBalancer) Report(addr string, latency time.Duration) {
+	if b.reports == nil {
+		b.reports = map[string]time.Duration{}
+	}
+	b.reports[addr] = latency
+}
+
+func TestDoDiscoveredReportsLatencyOnSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	endpoint := mustEndpoint(t, srv.URL)
+
+	balancer := &recordingBalancer{}
+	c := NewClient()
+	c.UseLoadBalancer(balancer)
+
+	req := &Request{Method: http.MethodGet, Url: srv.URL}
+	if _, err := c.DoDiscovered(context.Background(), req, endpoint.Address()); err != nil {
+		t.Fatalf("DoDiscovered returned error: %v", err)
+	}
+	if _, ok := balancer.reports[endpoint.Address()]; !ok {
+		t.Errorf("DoDiscovered did not report latency for %q", endpoint.Address())
+	}
+}
+
+func TestDoDiscoveredMarksEndpointUnhealthyOnFailure(t *testing.T) {
+	c := NewClient()
+	badAddr := "127.0.0.1:1"
+
+	req := &Request{Method: http.MethodGet, Url: "http://" + badAddr}
+	if _, err := c.DoDiscovered(context.Background(), req, badAddr); err == nil {
+		t.Fatal("expected a connection error against an unreachable address")
+	}
+
+	endpoint := Endpoint{Host: "127.0.0.1", Port: 1}
+	c.UseResolver(StaticResolver{"users": {endpoint}})
+	_, addr, err := c.ResolveBaseURL(context.Background(), "service://users")
+	if err != nil {
+		t.Fatalf("ResolveBaseURL returned error: %v", err)
+	}
+	// The only endpoint is now unhealthy, so ResolveBaseURL falls back to
+	// the full set - it still returns the same (only) endpoint, but the
+	// point is isHealthy actually observed the mark DoDiscovered made.
+	if addr != endpoint.Address() {
+		t.Errorf("addr = %q, want %q", addr, endpoint.Address())
+	}
+	if c.isHealthy(badAddr) {
+		t.Error("DoDiscovered should have marked the failing endpoint unhealthy")
+	}
+}
@@ -0,0 +1,151 @@
+package feign
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Stream issues req as a long-lived GET and decodes the response body as a
+// sequence of Server-Sent-Event or newline-delimited-JSON frames, sending
+// one decoded T per frame on the returned channel. It is the runtime behind
+// `@STREAM` tagged fields and the feigngen closures it generates.
+//
+// Streaming bypasses the Client.Use interceptor chain: a streamed response
+// is never buffered into a *Response, so interceptors written against Do
+// (logging, retry, auth) never see these calls. Set any headers the call
+// needs directly on req.
+//
+// Mid-stream errors (a malformed frame, a dropped connection) are sent on
+// the returned error channel rather than aborting the data channel
+// silently; decoding resumes after a bad frame. Both channels are closed
+// once the stream ends or ctx is canceled.
+func Stream[T any](ctx context.Context, c *Client, req *Request) (<-chan T, <-chan error) {
+	data := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(data)
+		defer close(errs)
+
+		r := c.R().SetContext(ctx).SetDoNotParseResponse(true)
+		for k, v := range req.Headers {
+			r.SetHeader(k, v)
+		}
+		if len(req.Query) > 0 {
+			r.SetQueryParams(req.Query)
+		}
+
+		resp, err := r.Execute(req.Method, req.Url)
+		if err != nil {
+			errs <- &HttpError{StatusCode: 0, Status: "connection error", Body: err.Error()}
+			return
+		}
+
+		if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+			raw, _ := io.ReadAll(resp.RawBody())
+			resp.RawBody().Close()
+			errs <- &HttpError{StatusCode: resp.StatusCode(), Status: resp.Status(), Body: string(raw)}
+			return
+		}
+
+		err = scanFrames(ctx, resp.RawBody(), func(frame []byte) bool {
+			var v T
+			if uerr := json.Unmarshal(frame, &v); uerr != nil {
+				select {
+				case errs <- fmt.Errorf("stream decode failed: %w", uerr):
+				case <-ctx.Done():
+				}
+				return true
+			}
+			select {
+			case data <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err != nil {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return data, errs
+}
+
+// FailedStream returns a stream that is already finished with err as its
+// only error, for callers that must report a failure - such as resolving a
+// `@Url service://...` endpoint - before any request is made.
+func FailedStream[T any](err error) (<-chan T, <-chan error) {
+	data := make(chan T)
+	errs := make(chan error, 1)
+	close(data)
+	errs <- err
+	close(errs)
+	return data, errs
+}
+
+// scanFrames reads body as a sequence of SSE ("event:"/"data:") or
+// newline-delimited-JSON frames, invoking onFrame with each decoded payload
+// until onFrame returns false, ctx is canceled, or the stream ends. It
+// always closes body before returning.
+func scanFrames(ctx context.Context, body io.ReadCloser, onFrame func(frame []byte) bool) error {
+	defer body.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			body.Close()
+		case <-done:
+		}
+	}()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var sse strings.Builder
+	flush := func() bool {
+		if sse.Len() == 0 {
+			return true
+		}
+		frame := sse.String()
+		sse.Reset()
+		return onFrame([]byte(frame))
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if !flush() {
+				return nil
+			}
+		case strings.HasPrefix(line, "data:"):
+			if sse.Len() > 0 {
+				sse.WriteByte('\n')
+			}
+			sse.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case strings.HasPrefix(line, "event:"), strings.HasPrefix(line, "id:"), strings.HasPrefix(line, ":"):
+			// SSE metadata fields are not part of the decoded payload.
+		default:
+			if !onFrame([]byte(line)) {
+				return nil
+			}
+		}
+	}
+	if !flush() {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,135 @@
+package feign
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+var errorInterfaceType = reflect.TypeOf((*error)(nil)).Elem()
+
+// makeStreamFunc builds the reflect.MakeFunc closure for an `@STREAM` tagged
+// field: methodType must be func(context.Context, ...) (<-chan T, <-chan error).
+// rawURL is the struct's `@Url` tag value, resolved per call the same way
+// Client.Create's non-stream closures resolve it.
+func makeStreamFunc(c *Client, fieldName string, methodType reflect.Type, meta MethodMeta, rawURL string) reflect.Value {
+	if methodType.NumOut() != 2 {
+		panic(fmt.Sprintf("%s: stream method must return (<-chan T, <-chan error)", fieldName))
+	}
+	dataChanType := methodType.Out(0)
+	errChanType := methodType.Out(1)
+	if dataChanType.Kind() != reflect.Chan || dataChanType.ChanDir() == reflect.SendDir {
+		panic(fmt.Sprintf("%s: first return value must be a receive-only channel", fieldName))
+	}
+	if errChanType.Kind() != reflect.Chan || errChanType.ChanDir() == reflect.SendDir || errChanType.Elem() != errorInterfaceType {
+		panic(fmt.Sprintf("%s: second return value must be a receive-only <-chan error", fieldName))
+	}
+	elemType := dataChanType.Elem()
+	// reflect.MakeChan only builds bidirectional channels; MakeFunc converts
+	// them to the method's receive-only result types on return.
+	dataChanType = reflect.ChanOf(reflect.BothDir, elemType)
+	errChanType = reflect.ChanOf(reflect.BothDir, errorInterfaceType)
+
+	return reflect.MakeFunc(methodType, func(args []reflect.Value) []reflect.Value {
+		ctx := args[0].Interface().(context.Context)
+
+		baseURL, _, err := c.ResolveBaseURL(ctx, rawURL)
+		if err != nil {
+			dataChan := reflect.MakeChan(dataChanType, 0)
+			errChan := reflect.MakeChan(errChanType, 1)
+			errChan.Send(reflect.ValueOf(err))
+			errChan.Close()
+			dataChan.Close()
+			return []reflect.Value{dataChan, errChan}
+		}
+
+		j := 1
+		pathProcessed := meta.Path
+		for _, p := range meta.PathVars {
+			pathProcessed = strings.ReplaceAll(pathProcessed, fmt.Sprintf("{%s}", p), fmt.Sprintf("%v", args[j].Interface()))
+			j++
+		}
+
+		queryParams := map[string]string{}
+		for _, q := range meta.Queries {
+			queryParams[q] = fmt.Sprintf("%v", args[j].Interface())
+			j++
+		}
+
+		headersMap := map[string]string{}
+		for _, h := range meta.Headers {
+			headersMap[h] = fmt.Sprintf("%v", args[j].Interface())
+			j++
+		}
+
+		req := &Request{
+			Method:  meta.Method,
+			Url:     baseURL + pathProcessed,
+			Headers: headersMap,
+			Query:   queryParams,
+		}
+
+		dataChan := reflect.MakeChan(dataChanType, 0)
+		errChan := reflect.MakeChan(errChanType, 1)
+
+		go runStream(ctx, c, req, elemType, dataChan, errChan)
+
+		return []reflect.Value{dataChan, errChan}
+	})
+}
+
+// runStream is the reflect-typed twin of Stream[T]: it drives the same
+// SetDoNotParseResponse + scanFrames pipeline but decodes into a
+// reflect.Value of elemType and sends via reflect.Value channels, since the
+// element type is only known at runtime here.
+func runStream(ctx context.Context, c *Client, req *Request, elemType reflect.Type, dataChan, errChan reflect.Value) {
+	defer dataChan.Close()
+	defer errChan.Close()
+
+	r := c.R().SetContext(ctx).SetDoNotParseResponse(true)
+	for k, v := range req.Headers {
+		r.SetHeader(k, v)
+	}
+	if len(req.Query) > 0 {
+		r.SetQueryParams(req.Query)
+	}
+
+	resp, err := r.Execute(req.Method, req.Url)
+	if err != nil {
+		trySend(ctx, errChan, reflect.ValueOf(error(&HttpError{StatusCode: 0, Status: "connection error", Body: err.Error()})))
+		return
+	}
+
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		raw, _ := io.ReadAll(resp.RawBody())
+		resp.RawBody().Close()
+		trySend(ctx, errChan, reflect.ValueOf(error(&HttpError{StatusCode: resp.StatusCode(), Status: resp.Status(), Body: string(raw)})))
+		return
+	}
+
+	err = scanFrames(ctx, resp.RawBody(), func(frame []byte) bool {
+		out := reflect.New(elemType)
+		if uerr := json.Unmarshal(frame, out.Interface()); uerr != nil {
+			trySend(ctx, errChan, reflect.ValueOf(fmt.Errorf("stream decode failed: %w", uerr)))
+			return true
+		}
+		return trySend(ctx, dataChan, out.Elem())
+	})
+	if err != nil {
+		trySend(ctx, errChan, reflect.ValueOf(err))
+	}
+}
+
+// trySend sends v on ch, returning false instead of blocking forever if ctx
+// is canceled first.
+func trySend(ctx context.Context, ch, v reflect.Value) bool {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: ch, Send: v},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	}
+	chosen, _, _ := reflect.Select(cases)
+	return chosen == 0
+}
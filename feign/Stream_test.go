@@ -0,0 +1,195 @@
+package feign
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanFramesSSEMultiDataJoin(t *testing.T) {
+	body := "event: message\ndata: line one\ndata: line two\n\n"
+	var got []string
+	err := scanFrames(context.Background(), io.NopCloser(strings.NewReader(body)), func(frame []byte) bool {
+		got = append(got, string(frame))
+		return true
+	})
+	if err != nil {
+		t.Fatalf("scanFrames returned error: %v", err)
+	}
+	want := []string{"line one\nline two"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("scanFrames frames = %#v, want %#v", got, want)
+	}
+}
+
+func TestScanFramesNDJSON(t *testing.T) {
+	body := `{"id":1}` + "\n" + `{"id":2}` + "\n"
+	var got []string
+	err := scanFrames(context.Background(), io.NopCloser(strings.NewReader(body)), func(frame []byte) bool {
+		got = append(got, string(frame))
+		return true
+	})
+	if err != nil {
+		t.Fatalf("scanFrames returned error: %v", err)
+	}
+	want := []string{`{"id":1}`, `{"id":2}`}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("scanFrames frames = %#v, want %#v", got, want)
+	}
+}
+
+func TestScanFramesMalformedFrameResumes(t *testing.T) {
+	body := `{"id":1}` + "\n" + `not json` + "\n" + `{"id":2}` + "\n"
+	var got []string
+	err := scanFrames(context.Background(), io.NopCloser(strings.NewReader(body)), func(frame []byte) bool {
+		if string(frame) == "not json" {
+			// Simulate Stream[T]'s onFrame: report the bad frame but keep going.
+			return true
+		}
+		got = append(got, string(frame))
+		return true
+	})
+	if err != nil {
+		t.Fatalf("scanFrames returned error: %v", err)
+	}
+	want := []string{`{"id":1}`, `{"id":2}`}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("scanFrames frames after malformed frame = %#v, want %#v", got, want)
+	}
+}
+
+func TestScanFramesCtxCancellationClosesPromptly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- scanFrames(ctx, pr, func(frame []byte) bool { return true })
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("scanFrames should return an error when ctx is canceled mid-read")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("scanFrames did not return promptly after ctx cancellation")
+	}
+}
+
+func newSSEServer(t *testing.T, frames []string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("response writer does not support flushing")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, frame := range frames {
+			fmt.Fprint(w, frame)
+			flusher.Flush()
+		}
+	}))
+}
+
+type streamTestEvent struct {
+	ID int `json:"id"`
+}
+
+// TestStreamEndToEnd exercises Stream[T] against a real server: SSE framing,
+// NDJSON framing, and a malformed frame reported on the error channel
+// without stopping the data channel.
+func TestStreamEndToEnd(t *testing.T) {
+	srv := newSSEServer(t, []string{
+		"data: {\"id\":1}\n\n",
+		"not json\n",
+		"data: {\"id\":2}\n\n",
+	})
+	defer srv.Close()
+
+	c := NewClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, errs := Stream[streamTestEvent](ctx, c, &Request{Method: http.MethodGet, Url: srv.URL})
+
+	var got []streamTestEvent
+	var gotErr error
+	for data != nil || errs != nil {
+		select {
+		case v, ok := <-data:
+			if !ok {
+				data = nil
+				continue
+			}
+			got = append(got, v)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			gotErr = err
+		case <-ctx.Done():
+			t.Fatal("stream did not complete before the test timeout")
+		}
+	}
+
+	want := []streamTestEvent{{ID: 1}, {ID: 2}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Stream data = %#v, want %#v", got, want)
+	}
+	if gotErr == nil {
+		t.Error("Stream should have reported the malformed frame on the error channel")
+	}
+}
+
+// TestStreamCtxCancellationClosesChannels confirms canceling ctx closes both
+// the data and error channels promptly, even mid-stream.
+func TestStreamCtxCancellationClosesChannels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("response writer does not support flushing")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "data: {\"id\":1}\n\n")
+		flusher.Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	data, errs := Stream[streamTestEvent](ctx, c, &Request{Method: http.MethodGet, Url: srv.URL})
+
+	<-data // wait for the first frame so the stream is actually established
+	cancel()
+
+	dataClosed, errsClosed := false, false
+	deadline := time.After(2 * time.Second)
+	for !dataClosed || !errsClosed {
+		select {
+		case _, ok := <-data:
+			if !ok {
+				dataClosed = true
+			}
+		case _, ok := <-errs:
+			if !ok {
+				errsClosed = true
+			}
+		case <-deadline:
+			t.Fatal("data/error channels were not closed promptly after ctx cancellation")
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+// Auth attaches an Authorization header produced by tokenFunc to every
+// request, refreshing the token on every call.
+func Auth(tokenFunc func(ctx context.Context) (string, error)) feign.Interceptor {
+	return func(next feign.Invoker) feign.Invoker {
+		return func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+			token, err := tokenFunc(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("feign: auth: %w", err)
+			}
+			if req.Headers == nil {
+				req.Headers = map[string]string{}
+			}
+			req.Headers["Authorization"] = token
+			return next(ctx, req)
+		}
+	}
+}
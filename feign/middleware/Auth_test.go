@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+func TestAuthSetsAuthorizationHeader(t *testing.T) {
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		if req.Headers["Authorization"] != "Bearer tok" {
+			t.Errorf("Authorization header = %q, want %q", req.Headers["Authorization"], "Bearer tok")
+		}
+		return &feign.Response{StatusCode: 200}, nil
+	}
+
+	invoke := Auth(func(ctx context.Context) (string, error) { return "Bearer tok", nil })(next)
+	if _, err := invoke(context.Background(), &feign.Request{}); err != nil {
+		t.Fatalf("invoke returned error: %v", err)
+	}
+}
+
+func TestAuthPropagatesTokenFuncError(t *testing.T) {
+	called := false
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		called = true
+		return &feign.Response{}, nil
+	}
+
+	invoke := Auth(func(ctx context.Context) (string, error) { return "", errors.New("token unavailable") })(next)
+	if _, err := invoke(context.Background(), &feign.Request{}); err == nil {
+		t.Fatal("expected an error when tokenFunc fails")
+	}
+	if called {
+		t.Error("next should not be called when tokenFunc fails")
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+// Logging logs the method, URL, outcome and latency of every call that
+// passes through it.
+func Logging() feign.Interceptor {
+	return func(next feign.Invoker) feign.Invoker {
+		return func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+			if err != nil {
+				log.Printf("feign: %s %s failed after %s: %v", req.Method, req.Url, time.Since(start), err)
+				return resp, err
+			}
+			log.Printf("feign: %s %s -> %d in %s", req.Method, req.Url, resp.StatusCode, time.Since(start))
+			return resp, nil
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+func TestLoggingPassesThroughSuccess(t *testing.T) {
+	want := &feign.Response{StatusCode: 204}
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		return want, nil
+	}
+
+	invoke := Logging()(next)
+	resp, err := invoke(context.Background(), &feign.Request{Method: "GET", Url: "http://example.test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp != want {
+		t.Errorf("resp = %v, want %v", resp, want)
+	}
+}
+
+func TestLoggingPassesThroughError(t *testing.T) {
+	wantErr := errors.New("boom")
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		return nil, wantErr
+	}
+
+	invoke := Logging()(next)
+	_, err := invoke(context.Background(), &feign.Request{Method: "GET", Url: "http://example.test"})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+}
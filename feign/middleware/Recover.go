@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+// Recover turns a panic anywhere further down the chain into an error
+// instead of crashing the caller.
+func Recover() feign.Interceptor {
+	return func(next feign.Invoker) feign.Invoker {
+		return func(ctx context.Context, req *feign.Request) (resp *feign.Response, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("feign: recovered from panic in %s %s: %v", req.Method, req.Url, r)
+				}
+			}()
+			return next(ctx, req)
+		}
+	}
+}
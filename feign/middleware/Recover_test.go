@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+func TestRecoverTurnsPanicIntoError(t *testing.T) {
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		panic("boom")
+	}
+
+	invoke := Recover()(next)
+	resp, err := invoke(context.Background(), &feign.Request{Method: "GET", Url: "http://example.test"})
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if resp != nil {
+		t.Errorf("expected a nil response alongside the recovered error, got %+v", resp)
+	}
+	if !strings.Contains(err.Error(), "boom") {
+		t.Errorf("error = %q, want it to mention the panic value %q", err.Error(), "boom")
+	}
+}
+
+func TestRecoverPassesThroughWhenNoPanic(t *testing.T) {
+	want := &feign.Response{StatusCode: 200}
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		return want, nil
+	}
+
+	invoke := Recover()(next)
+	resp, err := invoke(context.Background(), &feign.Request{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp != want {
+		t.Errorf("resp = %v, want %v", resp, want)
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+// Retry retries a call up to attempts additional times, waiting wait between
+// tries. It replaces resty's own retry so the decision can inspect the
+// decoded *feign.Response rather than just the transport error. shouldRetry
+// is called after every attempt but the last with the response from next
+// (nil if next returned an error); a nil shouldRetry defaults to retrying on any
+// transport error or 5xx status.
+func Retry(attempts int, wait time.Duration, shouldRetry func(resp *feign.Response, err error) bool) feign.Interceptor {
+	if shouldRetry == nil {
+		shouldRetry = func(resp *feign.Response, err error) bool {
+			return err != nil || resp.StatusCode >= 500
+		}
+	}
+	return func(next feign.Invoker) feign.Invoker {
+		return func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+			var resp *feign.Response
+			var err error
+			for attempt := 0; ; attempt++ {
+				resp, err = next(ctx, req)
+				if attempt >= attempts || !shouldRetry(resp, err) {
+					return resp, err
+				}
+				select {
+				case <-ctx.Done():
+					return resp, ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+		}
+	}
+}
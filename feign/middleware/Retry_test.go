@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+func TestRetryExhaustsAttempts(t *testing.T) {
+	calls := 0
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		calls++
+		return nil, errors.New("transport error")
+	}
+
+	invoke := Retry(2, time.Millisecond, nil)(next)
+	_, err := invoke(context.Background(), &feign.Request{})
+	if err == nil {
+		t.Fatal("expected an error once attempts are exhausted")
+	}
+	if calls != 3 {
+		t.Errorf("next was called %d times, want %d (1 initial + 2 retries)", calls, 3)
+	}
+}
+
+func TestRetryStopsAsSoonAsShouldRetryReturnsFalse(t *testing.T) {
+	calls := 0
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		calls++
+		return &feign.Response{StatusCode: 200}, nil
+	}
+
+	invoke := Retry(5, time.Millisecond, func(resp *feign.Response, err error) bool { return false })(next)
+	if _, err := invoke(context.Background(), &feign.Request{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("next was called %d times, want 1 (shouldRetry never asked for a retry)", calls)
+	}
+}
+
+func TestRetryStopsWhenCtxCanceledWhileWaiting(t *testing.T) {
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		calls++
+		if calls == 1 {
+			cancel()
+		}
+		return nil, errors.New("transport error")
+	}
+
+	invoke := Retry(5, time.Hour, nil)(next)
+	_, err := invoke(ctx, &feign.Request{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Errorf("next was called %d times, want 1 (ctx canceled during the first retry wait)", calls)
+	}
+}
+
+func TestRetryDefaultShouldRetryOn5xx(t *testing.T) {
+	calls := 0
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		calls++
+		if calls < 3 {
+			return &feign.Response{StatusCode: 503}, nil
+		}
+		return &feign.Response{StatusCode: 200}, nil
+	}
+
+	invoke := Retry(5, time.Millisecond, nil)(next)
+	resp, err := invoke(context.Background(), &feign.Request{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("resp.StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("next was called %d times, want 3 (2 retries on 503, then success)", calls)
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+// Timeout bounds every call that passes through it to d, regardless of the
+// timeout already configured on the underlying resty client.
+func Timeout(d time.Duration) feign.Interceptor {
+	return func(next feign.Invoker) feign.Invoker {
+		return func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kimxuanhong/go-feign/feign"
+)
+
+func TestTimeoutCancelsSlowCall(t *testing.T) {
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return &feign.Response{StatusCode: 200}, nil
+		}
+	}
+
+	invoke := Timeout(10 * time.Millisecond)(next)
+	_, err := invoke(context.Background(), &feign.Request{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestTimeoutLeavesFastCallUnaffected(t *testing.T) {
+	want := &feign.Response{StatusCode: 200}
+	next := func(ctx context.Context, req *feign.Request) (*feign.Response, error) {
+		return want, nil
+	}
+
+	invoke := Timeout(time.Second)(next)
+	resp, err := invoke(context.Background(), &feign.Request{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp != want {
+		t.Errorf("resp = %v, want %v", resp, want)
+	}
+}
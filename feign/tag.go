@@ -0,0 +1,85 @@
+package feign
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseTag parses the pipe-delimited `feign:"..."` struct tag grammar, e.g.
+//
+//	feign:"@GET /users/{id} | @Path id | @Query active | @Header Authorization"
+//
+// into a MethodMeta. This is the tag dialect consumed by cmd/feigngen; it is
+// also the dialect client structs are expected to write by hand when they
+// have no generated code and fall back to Client.Create.
+//
+// The blank base-URL field's `@Url` tag also accepts "service://<name>" to
+// resolve endpoints through a Client's Resolver and LoadBalancer instead of
+// a literal URL - see Client.ResolveBaseURL.
+//
+// `@Multipart` marks a method as a multipart/form-data upload, with each
+// `@Part` naming one parameter as a form field (rejecting `@Body` on the
+// same method); see FilePart for how parameter types map to file fields.
+func ParseTag(tag string) (MethodMeta, error) {
+	meta := MethodMeta{}
+	seen := map[string]bool{}
+
+	for _, segment := range strings.Split(tag, "|") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+		if !strings.HasPrefix(segment, "@") {
+			return meta, fmt.Errorf("invalid tag segment %q: must start with @", segment)
+		}
+
+		fields := strings.Fields(segment)
+		directive := strings.ToUpper(strings.TrimPrefix(fields[0], "@"))
+		var value string
+		if len(fields) > 1 {
+			value = fields[1]
+		}
+
+		repeatable := directive == "PATH" || directive == "QUERY" || directive == "HEADER" || directive == "PART"
+		if seen[directive] && !repeatable {
+			return meta, fmt.Errorf("duplicate tag: @%s", directive)
+		}
+		seen[directive] = true
+
+		switch directive {
+		case "GET", "POST", "PUT", "DELETE", "PATCH":
+			meta.Method = directive
+			meta.Path = value
+		case "STREAM":
+			meta.Method = "GET"
+			meta.Path = value
+			meta.Stream = true
+		case "URL":
+			meta.Url = value
+		case "PATH":
+			meta.PathVars = append(meta.PathVars, value)
+		case "QUERY":
+			meta.Queries = append(meta.Queries, value)
+		case "HEADER":
+			meta.Headers = append(meta.Headers, value)
+		case "BODY":
+			meta.BodyParam = value
+		case "CONSUMES":
+			meta.Consumes = value
+		case "PRODUCES":
+			meta.Produces = value
+		case "MULTIPART":
+			meta.Multipart = true
+		case "PART":
+			meta.Parts = append(meta.Parts, value)
+		default:
+			return meta, fmt.Errorf("unknown tag: @%s", directive)
+		}
+	}
+
+	if meta.Multipart && meta.BodyParam != "" {
+		return meta, fmt.Errorf("cannot combine @Multipart with @Body")
+	}
+
+	return meta, nil
+}